@@ -0,0 +1,97 @@
+package data
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Key algorithm identifiers used in TUF metadata.
+const (
+	ED25519Key = "ed25519"
+	RSAKey     = "rsa"
+	ECDSAKey   = "ecdsa"
+)
+
+// SigAlgorithm identifies the signature scheme used to produce a Signature.
+type SigAlgorithm string
+
+// Signature algorithm identifiers used in TUF metadata.
+const (
+	EDDSASignature  SigAlgorithm = "eddsa"
+	RSAPSSSignature SigAlgorithm = "rsapss"
+	ECDSASignature  SigAlgorithm = "ecdsa"
+)
+
+// Signature is a single signature over a block of signed data.
+type Signature struct {
+	KeyID     string       `json:"keyid"`
+	Method    SigAlgorithm `json:"method"`
+	Signature []byte       `json:"sig"`
+}
+
+// PublicKey is the public half of a TUF signing key.
+type PublicKey interface {
+	ID() string
+	Algorithm() string
+	Public() []byte
+}
+
+// PrivateKey is a PublicKey that also carries its private material, so it
+// can be used for signing.
+type PrivateKey interface {
+	PublicKey
+	Private() []byte
+}
+
+// TUFKey is the common representation of a public or private key, shared by
+// every algorithm this package supports.
+type TUFKey struct {
+	Type  string `json:"keytype"`
+	Value struct {
+		Public  []byte `json:"public"`
+		Private []byte `json:"private,omitempty"`
+	} `json:"keyval"`
+}
+
+// ID returns the key's identifier: the hex-encoded SHA256 of its algorithm
+// and public material.
+func (k *TUFKey) ID() string {
+	h := sha256.New()
+	h.Write([]byte(k.Type))
+	h.Write(k.Value.Public)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Algorithm returns the key type, e.g. "ed25519".
+func (k *TUFKey) Algorithm() string {
+	return k.Type
+}
+
+// Public returns the public key material.
+func (k *TUFKey) Public() []byte {
+	return k.Value.Public
+}
+
+// Private returns the private key material, if any.
+func (k *TUFKey) Private() []byte {
+	return k.Value.Private
+}
+
+func newPublicKey(algorithm string, public []byte) *TUFKey {
+	k := &TUFKey{Type: algorithm}
+	k.Value.Public = public
+	return k
+}
+
+func newPrivateKey(algorithm string, public, private []byte) *TUFKey {
+	k := &TUFKey{Type: algorithm}
+	k.Value.Public = public
+	k.Value.Private = private
+	return k
+}
+
+// PublicKeyFromPrivate strips the private material from priv, returning a
+// key that only satisfies PublicKey.
+func PublicKeyFromPrivate(priv PrivateKey) PublicKey {
+	return newPublicKey(priv.Algorithm(), priv.Public())
+}
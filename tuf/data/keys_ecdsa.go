@@ -0,0 +1,21 @@
+package data
+
+import "errors"
+
+// NewECDSAPublicKey returns a PublicKey for an ECDSA P-256 public key, in
+// uncompressed X9.62 form.
+func NewECDSAPublicKey(public []byte) *TUFKey {
+	return newPublicKey(ECDSAKey, public)
+}
+
+// NewECDSAPrivateKey returns a PrivateKey pairing pub with the raw ECDSA
+// private key bytes (the scalar D, big-endian). Callers that generate this
+// material, such as generateECDSAKeyPair, are responsible for padding it to
+// the curve's byte size; this constructor does not enforce a fixed length
+// itself.
+func NewECDSAPrivateKey(pub TUFKey, private []byte) (PrivateKey, error) {
+	if len(private) == 0 {
+		return nil, errors.New("ecdsa: private key must not be empty")
+	}
+	return newPrivateKey(ECDSAKey, pub.Public(), private), nil
+}
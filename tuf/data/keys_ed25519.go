@@ -0,0 +1,22 @@
+package data
+
+import "errors"
+
+const (
+	ed25519PublicKeySize  = 32
+	ed25519PrivateKeySize = 64
+)
+
+// NewED25519PublicKey returns a PublicKey for an ED25519 public key.
+func NewED25519PublicKey(public []byte) *TUFKey {
+	return newPublicKey(ED25519Key, public)
+}
+
+// NewED25519PrivateKey returns a PrivateKey pairing pub with the raw ED25519
+// private key bytes.
+func NewED25519PrivateKey(pub TUFKey, private []byte) (PrivateKey, error) {
+	if len(private) != ed25519PrivateKeySize {
+		return nil, errors.New("ed25519: private key has the wrong length")
+	}
+	return newPrivateKey(ED25519Key, pub.Public(), private), nil
+}
@@ -0,0 +1,33 @@
+package data
+
+import "errors"
+
+// ED448Key identifies an Ed448 key, offering a higher security level (224
+// bits) than ED25519Key for users willing to pay its larger key and
+// signature sizes.
+const ED448Key = "ed448"
+
+// ED448Signature identifies a signature produced by an Ed448 key.
+const ED448Signature SigAlgorithm = "ed448"
+
+const (
+	ed448PublicKeySize  = 57
+	ed448PrivateKeySize = 114
+)
+
+// NewED448PublicKey returns a PublicKey for an Ed448 public key.
+func NewED448PublicKey(public []byte) (*TUFKey, error) {
+	if len(public) != ed448PublicKeySize {
+		return nil, errors.New("ed448: public key has the wrong length")
+	}
+	return newPublicKey(ED448Key, public), nil
+}
+
+// NewED448PrivateKey returns a PrivateKey pairing pub with the raw Ed448
+// private key bytes.
+func NewED448PrivateKey(pub TUFKey, private []byte) (PrivateKey, error) {
+	if len(private) != ed448PrivateKeySize {
+		return nil, errors.New("ed448: private key has the wrong length")
+	}
+	return newPrivateKey(ED448Key, pub.Public(), private), nil
+}
@@ -0,0 +1,19 @@
+package data
+
+import "errors"
+
+// NewRSAPublicKey returns a PublicKey for an RSA public key, DER-encoded as
+// a PKIX SubjectPublicKeyInfo.
+func NewRSAPublicKey(public []byte) *TUFKey {
+	return newPublicKey(RSAKey, public)
+}
+
+// NewRSAPrivateKey returns a PrivateKey pairing pub with a PKCS#1-encoded
+// RSA private key. Unlike ED25519 and ECDSA, RSA keys are only ever
+// imported: this cryptoservice has no way to generate one.
+func NewRSAPrivateKey(pub TUFKey, private []byte) (PrivateKey, error) {
+	if len(private) == 0 {
+		return nil, errors.New("rsa: private key must not be empty")
+	}
+	return newPrivateKey(RSAKey, pub.Public(), private), nil
+}
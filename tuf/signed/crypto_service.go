@@ -0,0 +1,298 @@
+package signed
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/agl/ed25519"
+	"github.com/cloudflare/circl/sign/ed448"
+	"github.com/docker/notary/tuf/data"
+	"github.com/docker/notary/tuf/trustmanager"
+)
+
+// CryptoService dispatches key creation and signing across one or more
+// trustmanager.KeyStore backends, choosing the concrete key type based on
+// the requested algorithm. Unlike Ed25519, it is not tied to a single
+// algorithm or a single in-memory map.
+type CryptoService struct {
+	keyStores []trustmanager.KeyStore
+}
+
+// NewCryptoService returns a CryptoService backed by the given key stores.
+// Lookups and removals are applied across all of them; new keys are stored
+// in the first one.
+func NewCryptoService(keyStores ...trustmanager.KeyStore) *CryptoService {
+	return &CryptoService{keyStores: keyStores}
+}
+
+// Create generates a new key for role/gun using algorithm, and stores it in
+// the first configured key store. RSA keys cannot be generated this way:
+// use ImportKey instead.
+func (cs *CryptoService) Create(role, gun, algorithm string) (data.PublicKey, error) {
+	if len(cs.keyStores) == 0 {
+		return nil, errors.New("no key stores configured")
+	}
+
+	var (
+		public  data.PublicKey
+		private data.PrivateKey
+		err     error
+	)
+	switch algorithm {
+	case data.ED25519Key:
+		public, private, err = generateED25519Key()
+	case data.ECDSAKey:
+		public, private, err = generateECDSAKeyPair()
+	case data.ED448Key:
+		public, private, err = generateED448KeyPair()
+	case data.RSAKey:
+		return nil, errors.New("RSA keys cannot be generated, only imported")
+	default:
+		return nil, fmt.Errorf("unsupported algorithm: %s", algorithm)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cs.keyStores[0].AddKey(role, gun, private); err != nil {
+		return nil, err
+	}
+	return public, nil
+}
+
+func generateED25519Key() (data.PublicKey, data.PrivateKey, error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	public := data.NewED25519PublicKey(pub[:])
+	private, err := data.NewED25519PrivateKey(*public, priv[:])
+	if err != nil {
+		return nil, nil, err
+	}
+	return public, private, nil
+}
+
+// findStore looks up which configured key store holds keyID, in order. A
+// store reporting GetPrivateKey with no error is considered to hold the
+// key, even if it returns a nil PrivateKey for a KMS-backed key.
+func (cs *CryptoService) findStore(keyID string) (trustmanager.KeyStore, error) {
+	for _, store := range cs.keyStores {
+		if _, _, err := store.GetPrivateKey(keyID); err == nil {
+			return store, nil
+		}
+	}
+	return nil, trustmanager.ErrKeyNotFound{KeyID: keyID}
+}
+
+// findPrivateKey looks up keyID across every configured key store, in
+// order, returning the first match.
+func (cs *CryptoService) findPrivateKey(keyID string) (data.PrivateKey, string, error) {
+	store, err := cs.findStore(keyID)
+	if err != nil {
+		return nil, "", err
+	}
+	return store.GetPrivateKey(keyID)
+}
+
+// Sign finds each requested key across the configured key stores and
+// produces a signature with it. A key whose private material isn't held
+// locally (e.g. a remote KMS key) is signed by delegating to its key
+// store's trustmanager.Signer implementation instead.
+func (cs *CryptoService) Sign(keyIDs []string, toSign []byte) ([]data.Signature, error) {
+	signatures := make([]data.Signature, 0, len(keyIDs))
+	for _, keyID := range keyIDs {
+		store, err := cs.findStore(keyID)
+		if err != nil {
+			return nil, err
+		}
+		priv, _, err := store.GetPrivateKey(keyID)
+		if err != nil {
+			return nil, err
+		}
+
+		if priv == nil {
+			signer, ok := store.(trustmanager.Signer)
+			if !ok {
+				return nil, fmt.Errorf("signed: key %s has no local private material and its key store (%s) does not support remote signing", keyID, store.Name())
+			}
+			sig, err := signer.SignWithKey(keyID, toSign)
+			if err != nil {
+				return nil, err
+			}
+			signatures = append(signatures, sig)
+			continue
+		}
+
+		var (
+			sig    []byte
+			method data.SigAlgorithm
+		)
+		switch priv.Algorithm() {
+		case data.ED25519Key:
+			privBytes := [ed25519.PrivateKeySize]byte{}
+			copy(privBytes[:], priv.Private())
+			s := ed25519.Sign(&privBytes, toSign)
+			sig, method = s[:], data.EDDSASignature
+		case data.ECDSAKey:
+			s, err := signECDSA(priv, toSign)
+			if err != nil {
+				return nil, err
+			}
+			sig, method = s, data.ECDSASignature
+		case data.ED448Key:
+			sig, method = ed448.Sign(ed448.PrivateKey(priv.Private()), toSign, ""), data.ED448Signature
+		default:
+			return nil, fmt.Errorf("unsupported signing algorithm: %s", priv.Algorithm())
+		}
+
+		signatures = append(signatures, data.Signature{
+			KeyID:     keyID,
+			Method:    method,
+			Signature: sig,
+		})
+	}
+	return signatures, nil
+}
+
+// GetKey returns the public part of keyID, or nil if it isn't found in any
+// configured key store.
+func (cs *CryptoService) GetKey(keyID string) data.PublicKey {
+	store, err := cs.findStore(keyID)
+	if err != nil {
+		return nil
+	}
+	return store.GetKey(keyID)
+}
+
+// GetPrivateKey returns the private key and role for keyID, searching every
+// configured key store.
+func (cs *CryptoService) GetPrivateKey(keyID string) (data.PrivateKey, string, error) {
+	return cs.findPrivateKey(keyID)
+}
+
+// PublicKeys returns the public part of each of keyIDs that can be found in
+// a configured key store; IDs that aren't found are omitted.
+func (cs *CryptoService) PublicKeys(keyIDs ...string) (map[string]data.PublicKey, error) {
+	k := make(map[string]data.PublicKey)
+	for _, keyID := range keyIDs {
+		if store, err := cs.findStore(keyID); err == nil {
+			if pub := store.GetKey(keyID); pub != nil {
+				k[keyID] = pub
+			}
+		}
+	}
+	return k, nil
+}
+
+// RemoveKey removes keyID from every configured key store that holds it.
+func (cs *CryptoService) RemoveKey(keyID string) error {
+	for _, store := range cs.keyStores {
+		if err := store.RemoveKey(keyID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListKeys returns the IDs of every key across all configured key stores
+// that belongs to role.
+func (cs *CryptoService) ListKeys(role string) []string {
+	var keyIDs []string
+	for keyID, keyRole := range cs.ListAllKeys() {
+		if keyRole == role {
+			keyIDs = append(keyIDs, keyID)
+		}
+	}
+	return keyIDs
+}
+
+// ListAllKeys returns a map of keyID to role, merged across every
+// configured key store.
+func (cs *CryptoService) ListAllKeys() map[string]string {
+	keys := make(map[string]string)
+	for _, store := range cs.keyStores {
+		for keyID, role := range store.ListAllKeys() {
+			keys[keyID] = role
+		}
+	}
+	return keys
+}
+
+// ExportKey encrypts the private key identified by keyID under passphrase
+// and returns it as a single PEM block, tagged with its role, key ID, and
+// algorithm so ImportKey can reconstruct it.
+func (cs *CryptoService) ExportKey(keyID string, passphrase []byte) ([]byte, error) {
+	priv, role, err := cs.findPrivateKey(keyID)
+	if err != nil {
+		return nil, err
+	}
+	if priv == nil {
+		return nil, fmt.Errorf("signed: key %s has no local private material to export", keyID)
+	}
+
+	pub := priv.Public()
+	raw := make([]byte, 4+len(pub)+len(priv.Private()))
+	binary.BigEndian.PutUint32(raw, uint32(len(pub)))
+	copy(raw[4:], pub)
+	copy(raw[4+len(pub):], priv.Private())
+
+	return encryptToPEM(map[string]string{
+		"role":      role,
+		"keyid":     keyID,
+		"algorithm": priv.Algorithm(),
+	}, raw, passphrase)
+}
+
+// ImportKey decrypts pemBytes under passphrase and adds the resulting key
+// to the first configured key store. As with Ed25519.ImportKey, a key whose
+// role header is "root" must be encrypted.
+func (cs *CryptoService) ImportKey(pemBytes []byte, passphrase []byte) error {
+	if len(cs.keyStores) == 0 {
+		return errors.New("no key stores configured")
+	}
+
+	headers, raw, decrypted, err := decryptFromPEM(pemBytes, passphrase)
+	if err != nil {
+		return err
+	}
+	role := headers["role"]
+	if !decrypted && role == "root" {
+		return ErrRootKeyNotEncrypted
+	}
+
+	if len(raw) < 4 {
+		return errors.New("signed: imported key material is truncated")
+	}
+	pubLen := binary.BigEndian.Uint32(raw)
+	if pubLen > uint32(len(raw)-4) {
+		return errors.New("signed: imported key material is truncated")
+	}
+	pubBytes := raw[4 : 4+pubLen]
+	privBytes := raw[4+pubLen:]
+
+	var private data.PrivateKey
+	switch headers["algorithm"] {
+	case data.ED25519Key:
+		private, err = data.NewED25519PrivateKey(*data.NewED25519PublicKey(pubBytes), privBytes)
+	case data.ECDSAKey:
+		private, err = data.NewECDSAPrivateKey(*data.NewECDSAPublicKey(pubBytes), privBytes)
+	case data.ED448Key:
+		var public *data.TUFKey
+		public, err = data.NewED448PublicKey(pubBytes)
+		if err == nil {
+			private, err = data.NewED448PrivateKey(*public, privBytes)
+		}
+	case data.RSAKey:
+		private, err = data.NewRSAPrivateKey(*data.NewRSAPublicKey(pubBytes), privBytes)
+	default:
+		return fmt.Errorf("signed: unsupported key algorithm: %s", headers["algorithm"])
+	}
+	if err != nil {
+		return err
+	}
+
+	return cs.keyStores[0].AddKey(role, "", private)
+}
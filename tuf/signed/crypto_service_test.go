@@ -0,0 +1,95 @@
+package signed
+
+import (
+	"testing"
+
+	"github.com/docker/notary/tuf/data"
+)
+
+func TestCryptoServiceCreateDispatchesPerAlgorithm(t *testing.T) {
+	cs := NewCryptoService(NewEd25519(), NewECDSA(nil), NewEd448())
+
+	for _, algorithm := range []string{data.ED25519Key, data.ECDSAKey, data.ED448Key} {
+		pub, err := cs.Create("targets", "", algorithm)
+		if err != nil {
+			t.Fatalf("Create(%s): %v", algorithm, err)
+		}
+		if pub.Algorithm() != algorithm {
+			t.Fatalf("Create(%s) produced a %s key", algorithm, pub.Algorithm())
+		}
+	}
+}
+
+func TestCryptoServiceFindsKeyInSecondConfiguredStore(t *testing.T) {
+	first, second := NewEd25519(), NewEd25519()
+	cs := NewCryptoService(first, second)
+
+	pub, err := second.Create("targets", data.ED25519Key)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if _, _, err := cs.GetPrivateKey(pub.ID()); err != nil {
+		t.Fatalf("GetPrivateKey did not find a key held only by the second store: %v", err)
+	}
+
+	sigs, err := cs.Sign([]string{pub.ID()}, []byte("attack at dawn"))
+	if err != nil {
+		t.Fatalf("Sign did not find a key held only by the second store: %v", err)
+	}
+	if len(sigs) != 1 || sigs[0].KeyID != pub.ID() {
+		t.Fatalf("Sign returned unexpected signatures: %+v", sigs)
+	}
+}
+
+func TestCryptoServiceRemoveKeyRemovesFromEveryStore(t *testing.T) {
+	first, second := NewEd25519(), NewEd25519()
+	cs := NewCryptoService(first, second)
+
+	pub, err := first.Create("targets", data.ED25519Key)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	// Register the same key ID in the second store too, so RemoveKey has
+	// to reach both to really remove it everywhere.
+	priv, _, err := first.GetPrivateKey(pub.ID())
+	if err != nil {
+		t.Fatalf("GetPrivateKey: %v", err)
+	}
+	if err := second.AddKey("targets", "", priv); err != nil {
+		t.Fatalf("AddKey: %v", err)
+	}
+
+	if err := cs.RemoveKey(pub.ID()); err != nil {
+		t.Fatalf("RemoveKey: %v", err)
+	}
+
+	if _, _, err := cs.GetPrivateKey(pub.ID()); err == nil {
+		t.Fatal("GetPrivateKey found a key RemoveKey should have removed from every store")
+	}
+}
+
+func TestCryptoServiceListAllKeysMergesAcrossStores(t *testing.T) {
+	first, second := NewEd25519(), NewEd25519()
+	cs := NewCryptoService(first, second)
+
+	pubA, err := first.Create("targets", data.ED25519Key)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	pubB, err := second.Create("snapshot", data.ED25519Key)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	keys := cs.ListAllKeys()
+	if len(keys) != 2 {
+		t.Fatalf("ListAllKeys() = %v, want 2 entries", keys)
+	}
+	if keys[pubA.ID()] != "targets" {
+		t.Fatalf("ListAllKeys()[%s] = %q, want %q", pubA.ID(), keys[pubA.ID()], "targets")
+	}
+	if keys[pubB.ID()] != "snapshot" {
+		t.Fatalf("ListAllKeys()[%s] = %q, want %q", pubB.ID(), keys[pubB.ID()], "snapshot")
+	}
+}
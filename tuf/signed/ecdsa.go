@@ -0,0 +1,244 @@
+package signed
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/docker/notary/tuf/data"
+)
+
+// KMSSigner is implemented by a remote signing backend: given the ID of a
+// key it holds, it produces a signature over msg without this process ever
+// seeing the private key material. This lets ECDSA keys live in a cloud KMS
+// (AWS KMS, GCP KMS, Azure Key Vault) instead of in memory.
+type KMSSigner interface {
+	// Sign returns a signature over msg produced by the key identified by
+	// keyID.
+	Sign(keyID string, msg []byte) ([]byte, error)
+}
+
+type ecdsaKey struct {
+	role    string
+	pubKey  data.PublicKey
+	privKey data.PrivateKey // nil for KMS-backed keys
+}
+
+// ECDSA implements a cryptoservice for P-256 keys. Keys generated with
+// Create are signed in-process; keys registered with AddKMSKey have no
+// local private material and are signed by delegating to the configured
+// KMSSigner, identified only by key ID.
+type ECDSA struct {
+	keys map[string]ecdsaKey
+	kms  KMSSigner
+}
+
+// NewECDSA initializes an empty ECDSA CryptoService backed by kms for any
+// KMS-registered keys. kms may be nil if every key will be local.
+func NewECDSA(kms KMSSigner) *ECDSA {
+	return &ECDSA{
+		keys: make(map[string]ecdsaKey),
+		kms:  kms,
+	}
+}
+
+func (e *ECDSA) addKey(role string, k data.PrivateKey) {
+	e.keys[k.ID()] = ecdsaKey{
+		role:    role,
+		pubKey:  data.PublicKeyFromPrivate(k),
+		privKey: k,
+	}
+}
+
+// AddKMSKey registers a key under role whose private material lives only
+// in the remote KMS; pub is the public half, already known.
+func (e *ECDSA) AddKMSKey(role string, pub data.PublicKey) {
+	e.keys[pub.ID()] = ecdsaKey{
+		role:   role,
+		pubKey: pub,
+	}
+}
+
+// AddKey stores privKey under role, satisfying trustmanager.KeyStore. gun is
+// ignored: this store is not GUN-scoped.
+func (e *ECDSA) AddKey(role, gun string, privKey data.PrivateKey) error {
+	e.addKey(role, privKey)
+	return nil
+}
+
+// Name identifies this key store backend, satisfying trustmanager.KeyStore.
+// It reports "kms" when backed by a remote KMSSigner, so callers can tell
+// local and remote-backed ECDSA stores apart in logs.
+func (e *ECDSA) Name() string {
+	if e.kms != nil {
+		return "kms"
+	}
+	return "memory"
+}
+
+// RemoveKey deletes a key from the signer
+func (e *ECDSA) RemoveKey(keyID string) error {
+	delete(e.keys, keyID)
+	return nil
+}
+
+// ListKeys returns the list of key IDs for the role
+func (e *ECDSA) ListKeys(role string) []string {
+	keyIDs := make([]string, 0, len(e.keys))
+	for id, k := range e.keys {
+		if k.role == role {
+			keyIDs = append(keyIDs, id)
+		}
+	}
+	return keyIDs
+}
+
+// ListAllKeys returns a map of keyID to role
+func (e *ECDSA) ListAllKeys() map[string]string {
+	keys := make(map[string]string)
+	for id, k := range e.keys {
+		keys[id] = k.role
+	}
+	return keys
+}
+
+// Create generates a new P-256 key pair in-process and returns its public
+// half.
+func (e *ECDSA) Create(role string) (data.PublicKey, error) {
+	public, private, err := generateECDSAKeyPair()
+	if err != nil {
+		return nil, err
+	}
+
+	e.addKey(role, private)
+	return public, nil
+}
+
+// generateECDSAKeyPair generates a new in-process P-256 key pair, shared by
+// ECDSA.Create and CryptoService's generic Create.
+func generateECDSAKeyPair() (data.PublicKey, data.PrivateKey, error) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	public := data.NewECDSAPublicKey(elliptic.Marshal(elliptic.P256(), priv.X, priv.Y))
+	private, err := data.NewECDSAPrivateKey(*public, ecdsaPrivateKeyBytes(priv))
+	if err != nil {
+		return nil, nil, err
+	}
+	return public, private, nil
+}
+
+// ecdsaPrivateKeyBytes serializes priv.D as a big-endian scalar padded with
+// leading zeros to the curve's byte size, so every serialized ECDSA private
+// key for a given curve has the same length regardless of how small D
+// happens to be.
+func ecdsaPrivateKeyBytes(priv *ecdsa.PrivateKey) []byte {
+	size := (priv.Curve.Params().BitSize + 7) / 8
+	b := make([]byte, size)
+	d := priv.D.Bytes()
+	copy(b[size-len(d):], d)
+	return b
+}
+
+// SignWithKey produces a signature over toSign using keyID, satisfying
+// trustmanager.Signer: locally if the key's private material is held in
+// memory, or by delegating to the configured KMSSigner if the key is
+// KMS-backed.
+func (e *ECDSA) SignWithKey(keyID string, toSign []byte) (data.Signature, error) {
+	key, ok := e.keys[keyID]
+	if !ok {
+		return data.Signature{}, fmt.Errorf("signed: unknown key: %s", keyID)
+	}
+
+	var (
+		sig []byte
+		err error
+	)
+	if key.privKey != nil {
+		sig, err = signECDSA(key.privKey, toSign)
+	} else if e.kms != nil {
+		sig, err = e.kms.Sign(keyID, toSign)
+	} else {
+		err = fmt.Errorf("signed: key %s is KMS-backed but no KMSSigner is configured", keyID)
+	}
+	if err != nil {
+		return data.Signature{}, err
+	}
+
+	return data.Signature{
+		KeyID:     keyID,
+		Method:    data.ECDSASignature,
+		Signature: sig,
+	}, nil
+}
+
+// Sign signs toSign with each of keyIDs, delegating each to SignWithKey.
+func (e *ECDSA) Sign(keyIDs []string, toSign []byte) ([]data.Signature, error) {
+	signatures := make([]data.Signature, 0, len(keyIDs))
+	for _, keyID := range keyIDs {
+		sig, err := e.SignWithKey(keyID, toSign)
+		if err != nil {
+			return nil, err
+		}
+		signatures = append(signatures, sig)
+	}
+	return signatures, nil
+}
+
+func signECDSA(priv data.PrivateKey, msg []byte) ([]byte, error) {
+	key := new(ecdsa.PrivateKey)
+	key.Curve = elliptic.P256()
+	key.D = new(big.Int).SetBytes(priv.Private())
+	key.X, key.Y = key.Curve.ScalarBaseMult(key.D.Bytes())
+
+	hashed := sha256.Sum256(msg)
+	return ecdsa.SignASN1(rand.Reader, key, hashed[:])
+}
+
+// Verify checks that sig is a valid ECDSA signature by pub over msg.
+func (e *ECDSA) Verify(pub data.PublicKey, msg, sig []byte) error {
+	x, y := elliptic.Unmarshal(elliptic.P256(), pub.Public())
+	if x == nil {
+		return errors.New("signed: invalid ECDSA public key")
+	}
+	key := &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+
+	hashed := sha256.Sum256(msg)
+	if !ecdsa.VerifyASN1(key, hashed[:], sig) {
+		return errors.New("signed: invalid ECDSA signature")
+	}
+	return nil
+}
+
+// PublicKeys returns a map of public keys for the ids provided, when those
+// IDs are found in the store.
+func (e *ECDSA) PublicKeys(keyIDs ...string) (map[string]data.PublicKey, error) {
+	k := make(map[string]data.PublicKey)
+	for _, keyID := range keyIDs {
+		if key, ok := e.keys[keyID]; ok {
+			k[keyID] = key.pubKey
+		}
+	}
+	return k, nil
+}
+
+// GetKey returns a single public key based on the ID
+func (e *ECDSA) GetKey(keyID string) data.PublicKey {
+	return e.keys[keyID].pubKey
+}
+
+// GetPrivateKey returns a single private key based on the ID. For a
+// KMS-backed key this returns a nil PrivateKey: the material never leaves
+// the KMS.
+func (e *ECDSA) GetPrivateKey(keyID string) (data.PrivateKey, string, error) {
+	key, ok := e.keys[keyID]
+	if !ok {
+		return nil, "", fmt.Errorf("signed: unknown key: %s", keyID)
+	}
+	return key.privKey, key.role, nil
+}
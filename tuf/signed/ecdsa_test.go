@@ -0,0 +1,99 @@
+package signed
+
+import (
+	"testing"
+
+	"github.com/docker/notary/tuf/data"
+	"github.com/docker/notary/tuf/trustmanager"
+)
+
+func TestECDSAIsAKeyStore(t *testing.T) {
+	var _ trustmanager.KeyStore = NewECDSA(nil)
+	var _ trustmanager.Signer = NewECDSA(nil)
+}
+
+func TestECDSACreateSignVerify(t *testing.T) {
+	e := NewECDSA(nil)
+
+	pub, err := e.Create("targets")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	msg := []byte("attack at dawn")
+	sigs, err := e.Sign([]string{pub.ID()}, msg)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if len(sigs) != 1 || sigs[0].Method != data.ECDSASignature {
+		t.Fatalf("Sign returned unexpected signatures: %+v", sigs)
+	}
+
+	if err := e.Verify(pub, msg, sigs[0].Signature); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if err := e.Verify(pub, []byte("a different message"), sigs[0].Signature); err == nil {
+		t.Fatal("Verify accepted a signature over the wrong message")
+	}
+}
+
+func TestECDSASignWithoutKMSFails(t *testing.T) {
+	e := NewECDSA(nil)
+	pub, err := NewStubKMSSigner().Generate()
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	e.AddKMSKey("targets", pub)
+
+	if _, err := e.Sign([]string{pub.ID()}, []byte("attack at dawn")); err == nil {
+		t.Fatal("Sign succeeded on a KMS-backed key with no KMSSigner configured")
+	}
+}
+
+func TestECDSADelegatesKMSBackedKeysToKMSSigner(t *testing.T) {
+	kms := NewStubKMSSigner()
+	pub, err := kms.Generate()
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	e := NewECDSA(kms)
+	e.AddKMSKey("targets", pub)
+
+	msg := []byte("attack at dawn")
+	sigs, err := e.Sign([]string{pub.ID()}, msg)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if err := e.Verify(pub, msg, sigs[0].Signature); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+
+	if _, _, err := e.GetPrivateKey(pub.ID()); err != nil {
+		t.Fatalf("GetPrivateKey: %v", err)
+	}
+	if priv, _, _ := e.GetPrivateKey(pub.ID()); priv != nil {
+		t.Fatal("GetPrivateKey returned private material for a KMS-backed key")
+	}
+}
+
+func TestECDSACryptoServiceSignsThroughKMSDelegation(t *testing.T) {
+	kms := NewStubKMSSigner()
+	pub, err := kms.Generate()
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	store := NewECDSA(kms)
+	store.AddKMSKey("targets", pub)
+
+	cs := NewCryptoService(store)
+	msg := []byte("attack at dawn")
+	sigs, err := cs.Sign([]string{pub.ID()}, msg)
+	if err != nil {
+		t.Fatalf("CryptoService.Sign did not delegate to the KMS-backed store: %v", err)
+	}
+	if err := store.Verify(pub, msg, sigs[0].Signature); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package signed
 import (
 	"crypto/rand"
 	"errors"
+	"fmt"
 
 	"github.com/agl/ed25519"
 	"github.com/docker/notary/tuf/data"
@@ -16,13 +17,18 @@ type edCryptoKey struct {
 // Ed25519 implements a simple in memory cryptosystem for ED25519 keys
 type Ed25519 struct {
 	keys map[string]edCryptoKey
+
+	// seed and chainCode are only set when the service was constructed
+	// with NewEd25519FromSeed, and back DeriveKey.
+	seed      []byte
+	chainCode []byte
 }
 
 // NewEd25519 initializes a new empty Ed25519 CryptoService that operates
 // entirely in memory
 func NewEd25519() *Ed25519 {
 	return &Ed25519{
-		make(map[string]edCryptoKey),
+		keys: make(map[string]edCryptoKey),
 	}
 }
 
@@ -34,6 +40,18 @@ func (e *Ed25519) addKey(role string, k data.PrivateKey) {
 	}
 }
 
+// AddKey stores privKey under role, satisfying trustmanager.KeyStore. gun is
+// ignored: this store is not GUN-scoped.
+func (e *Ed25519) AddKey(role, gun string, privKey data.PrivateKey) error {
+	e.addKey(role, privKey)
+	return nil
+}
+
+// Name identifies this key store backend, satisfying trustmanager.KeyStore.
+func (e *Ed25519) Name() string {
+	return "memory"
+}
+
 // RemoveKey deletes a key from the signer
 func (e *Ed25519) RemoveKey(keyID string) error {
 	delete(e.keys, keyID)
@@ -114,5 +132,9 @@ func (e *Ed25519) GetKey(keyID string) data.PublicKey {
 
 // GetPrivateKey returns a single private key based on the ID
 func (e *Ed25519) GetPrivateKey(keyID string) (data.PrivateKey, string, error) {
-	return e.keys[keyID].privKey, "", nil
+	edKey, ok := e.keys[keyID]
+	if !ok {
+		return nil, "", fmt.Errorf("signed: unknown key: %s", keyID)
+	}
+	return edKey.privKey, edKey.role, nil
 }
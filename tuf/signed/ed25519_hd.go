@@ -0,0 +1,95 @@
+package signed
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/agl/ed25519"
+	"github.com/docker/notary/tuf/data"
+)
+
+// ed25519SeedKey is the HMAC key SLIP-0010 fixes for deriving the master
+// node of an Ed25519 tree from a seed.
+const ed25519SeedKey = "ed25519 seed"
+
+// NewEd25519FromSeed initializes an Ed25519 CryptoService whose keys are
+// derived on demand from seed via DeriveKey, so an operator can back up one
+// seed phrase instead of N raw keys for the targets/snapshot/timestamp
+// roles.
+func NewEd25519FromSeed(seed []byte) *Ed25519 {
+	mac := hmac.New(sha512.New, []byte(ed25519SeedKey))
+	mac.Write(seed)
+	i := mac.Sum(nil)
+
+	return &Ed25519{
+		keys:      make(map[string]edCryptoKey),
+		seed:      i[:32],
+		chainCode: i[32:],
+	}
+}
+
+// DeriveKey derives the Ed25519 key at path from e's seed and stores it
+// under role, exactly as Create would store a randomly generated one, so
+// the rest of the CryptoService API works unchanged. path looks like
+// "m/0'/1'/2'": per SLIP-0010, only hardened derivation is defined for
+// Ed25519, so every segment is treated as hardened whether or not it
+// carries a trailing '.
+func (e *Ed25519) DeriveKey(role string, path string) (data.PublicKey, error) {
+	if e.seed == nil {
+		return nil, errors.New("ed25519: cryptoservice was not constructed with NewEd25519FromSeed")
+	}
+
+	priv, chainCode := e.seed, e.chainCode
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "" || segment == "m" {
+			continue
+		}
+		index, err := parseHardenedIndex(segment)
+		if err != nil {
+			return nil, err
+		}
+		priv, chainCode = deriveChildKey(priv, chainCode, index)
+	}
+
+	pub, edPriv, err := ed25519.GenerateKey(bytes.NewReader(priv))
+	if err != nil {
+		return nil, err
+	}
+	public := data.NewED25519PublicKey(pub[:])
+	private, err := data.NewED25519PrivateKey(*public, edPriv[:])
+	if err != nil {
+		return nil, err
+	}
+
+	e.addKey(role, private)
+	return public, nil
+}
+
+func parseHardenedIndex(segment string) (uint32, error) {
+	n, err := strconv.ParseUint(strings.TrimSuffix(segment, "'"), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("ed25519: invalid derivation path segment %q: %v", segment, err)
+	}
+	return uint32(n), nil
+}
+
+// deriveChildKey computes the SLIP-0010 hardened child private key and
+// chain code at index, from the parent private key and chain code.
+func deriveChildKey(parentPriv, parentChainCode []byte, index uint32) (childPriv, childChainCode []byte) {
+	var ser32 [4]byte
+	binary.BigEndian.PutUint32(ser32[:], index|0x80000000)
+
+	mac := hmac.New(sha512.New, parentChainCode)
+	mac.Write([]byte{0x00})
+	mac.Write(parentPriv)
+	mac.Write(ser32[:])
+	i := mac.Sum(nil)
+
+	return i[:32], i[32:]
+}
@@ -0,0 +1,169 @@
+package signed
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/agl/ed25519"
+)
+
+// TestDeriveKeyMasterMatchesSLIP0010TestVector checks the master key of
+// e.seed/e.chainCode — the output of NewEd25519FromSeed, before any
+// hardened derivation — against SLIP-0010's published "Test vector 1 for
+// ed25519", https://github.com/satoshilabs/slips/blob/master/slip-0010.md.
+// The published public key is 33 bytes (a leading 0x00 the spec prepends
+// to every curve's compressed point for presentation, followed by the raw
+// 32-byte Edwards point); pub.Public() is just the latter.
+func TestDeriveKeyMasterMatchesSLIP0010TestVector(t *testing.T) {
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	if err != nil {
+		t.Fatalf("decoding test vector seed: %v", err)
+	}
+	wantHex := "a4b2856bfec510abab89753fac1ac0e1112364e7d250545963f135f2a33188ed"
+	want, err := hex.DecodeString(wantHex)
+	if err != nil {
+		t.Fatalf("decoding test vector public key: %v", err)
+	}
+
+	pub, err := NewEd25519FromSeed(seed).DeriveKey("targets", "m")
+	if err != nil {
+		t.Fatalf("DeriveKey(\"m\"): %v", err)
+	}
+
+	if !bytes.Equal(pub.Public(), want) {
+		t.Fatalf("master public key = %x, want %x (SLIP-0010 test vector 1)", pub.Public(), want)
+	}
+}
+
+// referenceDeriveChildKey computes the same SLIP-0010 hardened child
+// formula as deriveChildKey, but built out of a differently-shaped HMAC
+// call (one Write of a pre-built buffer instead of three separate Writes,
+// and manual big-endian byte packing instead of encoding/binary) so this
+// test can catch a transcription bug in deriveChildKey rather than just
+// confirming it against an identical copy of itself.
+func referenceDeriveChildKey(parentPriv, parentChainCode []byte, index uint32) (childPriv, childChainCode []byte) {
+	hardened := index | 0x80000000
+
+	buf := make([]byte, 0, 1+len(parentPriv)+4)
+	buf = append(buf, 0x00)
+	buf = append(buf, parentPriv...)
+	buf = append(buf,
+		byte(hardened>>24),
+		byte(hardened>>16),
+		byte(hardened>>8),
+		byte(hardened),
+	)
+
+	mac := hmac.New(sha512.New, parentChainCode)
+	mac.Write(buf)
+	i := mac.Sum(nil)
+	return i[:32], i[32:]
+}
+
+func referenceDeriveKey(seed []byte, path string) (pub [ed25519.PublicKeySize]byte) {
+	mac := hmac.New(sha512.New, []byte("ed25519 seed"))
+	mac.Write(seed)
+	i := mac.Sum(nil)
+	priv, chainCode := i[:32], i[32:]
+
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "" || segment == "m" {
+			continue
+		}
+		n, err := strconv.ParseUint(strings.TrimSuffix(segment, "'"), 10, 32)
+		if err != nil {
+			panic(err)
+		}
+		priv, chainCode = referenceDeriveChildKey(priv, chainCode, uint32(n))
+	}
+
+	p, _, err := ed25519.GenerateKey(bytes.NewReader(priv))
+	if err != nil {
+		panic(err)
+	}
+	return *p
+}
+
+func TestDeriveKeyMatchesIndependentReferenceImplementation(t *testing.T) {
+	seed := []byte("a sufficiently long seed phrase")
+
+	for _, path := range []string{"m/0'", "m/44'/0'/0'", "m/1'/2'/3'/4'"} {
+		e := NewEd25519FromSeed(seed)
+		pub, err := e.DeriveKey("targets", path)
+		if err != nil {
+			t.Fatalf("DeriveKey(%q): %v", path, err)
+		}
+
+		want := referenceDeriveKey(seed, path)
+		if !bytes.Equal(pub.Public(), want[:]) {
+			t.Fatalf("DeriveKey(%q) = %x, want %x", path, pub.Public(), want)
+		}
+	}
+}
+
+func TestDeriveKeyIsDeterministic(t *testing.T) {
+	seed := []byte("another seed phrase entirely")
+
+	pubA, err := NewEd25519FromSeed(seed).DeriveKey("targets", "m/1'/2'")
+	if err != nil {
+		t.Fatalf("DeriveKey: %v", err)
+	}
+	pubB, err := NewEd25519FromSeed(seed).DeriveKey("targets", "m/1'/2'")
+	if err != nil {
+		t.Fatalf("DeriveKey: %v", err)
+	}
+
+	if !bytes.Equal(pubA.Public(), pubB.Public()) {
+		t.Fatal("DeriveKey is not deterministic for a fixed seed and path")
+	}
+}
+
+func TestDeriveKeyDifferentPathsDifferentKeys(t *testing.T) {
+	e := NewEd25519FromSeed([]byte("yet another seed phrase"))
+
+	pubA, err := e.DeriveKey("targets", "m/0'")
+	if err != nil {
+		t.Fatalf("DeriveKey: %v", err)
+	}
+	pubB, err := e.DeriveKey("snapshot", "m/1'")
+	if err != nil {
+		t.Fatalf("DeriveKey: %v", err)
+	}
+
+	if bytes.Equal(pubA.Public(), pubB.Public()) {
+		t.Fatal("different derivation paths produced the same key")
+	}
+}
+
+func TestDeriveKeyStoresUnderRoleAndCanSign(t *testing.T) {
+	e := NewEd25519FromSeed([]byte("sign me a seed phrase"))
+
+	pub, err := e.DeriveKey("targets", "m/0'")
+	if err != nil {
+		t.Fatalf("DeriveKey: %v", err)
+	}
+
+	ids := e.ListKeys("targets")
+	if len(ids) != 1 || ids[0] != pub.ID() {
+		t.Fatalf("ListKeys(\"targets\") = %v, want [%s]", ids, pub.ID())
+	}
+
+	sigs, err := e.Sign([]string{pub.ID()}, []byte("attack at dawn"))
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if err := e.Verify(pub, sigs[0], []byte("attack at dawn")); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+}
+
+func TestDeriveKeyWithoutSeedErrors(t *testing.T) {
+	if _, err := NewEd25519().DeriveKey("targets", "m/0'"); err == nil {
+		t.Fatal("DeriveKey succeeded on a CryptoService with no seed")
+	}
+}
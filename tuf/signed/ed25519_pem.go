@@ -0,0 +1,179 @@
+package signed
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/agl/ed25519"
+	"github.com/docker/notary/tuf/data"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	encryptedBlockType = "ENCRYPTED PRIVATE KEY"
+
+	pemSaltSize  = 16
+	pemNonceSize = 12
+	pbkdf2Iter   = 100000
+	aesKeySize   = 32
+)
+
+// ErrRootKeyNotEncrypted is returned by ImportKey when a key with role
+// "root" arrives as a plaintext PEM block. Root keys must always be
+// encrypted at rest.
+var ErrRootKeyNotEncrypted = errors.New("signed: root keys must be encrypted")
+
+func deriveKey(passphrase, salt []byte) []byte {
+	return pbkdf2.Key(passphrase, salt, pbkdf2Iter, aesKeySize, sha256.New)
+}
+
+// encryptToPEM encrypts raw under passphrase with a freshly generated salt
+// and nonce, folding them into the PEM block's headers alongside extra.
+func encryptToPEM(extra map[string]string, raw, passphrase []byte) ([]byte, error) {
+	salt := make([]byte, pemSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, pemNonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(deriveKey(passphrase, salt))
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext := gcm.Seal(nil, nonce, raw, nil)
+
+	headers := map[string]string{
+		"salt":  hex.EncodeToString(salt),
+		"nonce": hex.EncodeToString(nonce),
+	}
+	for k, v := range extra {
+		headers[k] = v
+	}
+
+	return pem.EncodeToMemory(&pem.Block{
+		Type:    encryptedBlockType,
+		Headers: headers,
+		Bytes:   ciphertext,
+	}), nil
+}
+
+// decryptFromPEM parses a PEM block produced by encryptToPEM and decrypts
+// its contents under passphrase. If the block isn't encrypted, raw is
+// block.Bytes unchanged and decrypted is false.
+func decryptFromPEM(pemBytes, passphrase []byte) (headers map[string]string, raw []byte, decrypted bool, err error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, nil, false, errors.New("signed: no PEM block found")
+	}
+	if block.Type != encryptedBlockType {
+		return block.Headers, block.Bytes, false, nil
+	}
+
+	salt, err := hex.DecodeString(block.Headers["salt"])
+	if err != nil {
+		return nil, nil, false, err
+	}
+	nonce, err := hex.DecodeString(block.Headers["nonce"])
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	cipherBlock, err := aes.NewCipher(deriveKey(passphrase, salt))
+	if err != nil {
+		return nil, nil, false, err
+	}
+	gcm, err := cipher.NewGCM(cipherBlock)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	raw, err = gcm.Open(nil, nonce, block.Bytes, nil)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("signed: could not decrypt key (wrong passphrase?): %v", err)
+	}
+	return block.Headers, raw, true, nil
+}
+
+// ExportKey encrypts the private key identified by keyID under passphrase
+// and returns it as a single PEM block, with the role and key ID carried in
+// PEM headers.
+func (e *Ed25519) ExportKey(keyID string, passphrase []byte) ([]byte, error) {
+	edKey, ok := e.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("signed: no such key: %s", keyID)
+	}
+
+	raw := append(append([]byte{}, edKey.privKey.Public()...), edKey.privKey.Private()...)
+	return encryptToPEM(map[string]string{
+		"role":  edKey.role,
+		"keyid": keyID,
+	}, raw, passphrase)
+}
+
+// ImportKey decrypts pemBytes under passphrase and adds the resulting key
+// to the store. Keys whose role header is "root" must be encrypted: an
+// unencrypted root key is rejected with ErrRootKeyNotEncrypted.
+func (e *Ed25519) ImportKey(pemBytes []byte, passphrase []byte) error {
+	headers, raw, decrypted, err := decryptFromPEM(pemBytes, passphrase)
+	if err != nil {
+		return err
+	}
+	role := headers["role"]
+	if !decrypted && role == "root" {
+		return ErrRootKeyNotEncrypted
+	}
+
+	if len(raw) != ed25519.PublicKeySize+ed25519.PrivateKeySize {
+		return errors.New("signed: imported key material has the wrong length")
+	}
+
+	public := data.NewED25519PublicKey(raw[:ed25519.PublicKeySize])
+	private, err := data.NewED25519PrivateKey(*public, raw[ed25519.PublicKeySize:])
+	if err != nil {
+		return err
+	}
+
+	e.addKey(role, private)
+	return nil
+}
+
+// ExportAllKeys encrypts every key in the store under passphrase and
+// packages them as a ZIP archive of one PEM file per key, named by key ID,
+// so a user can back up their entire key set in one shot.
+func (e *Ed25519) ExportAllKeys(passphrase []byte) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+
+	for keyID := range e.keys {
+		pemBytes, err := e.ExportKey(keyID, passphrase)
+		if err != nil {
+			return nil, err
+		}
+		w, err := zw.Create(keyID + ".pem")
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(pemBytes); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,142 @@
+package signed
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/pem"
+	"testing"
+
+	"github.com/docker/notary/tuf/data"
+	"github.com/docker/notary/tuf/trustmanager"
+)
+
+func TestEd25519ExportImportKeyRoundTrip(t *testing.T) {
+	passphrase := []byte("correct horse battery staple")
+
+	e := NewEd25519()
+	pub, err := e.Create("targets", data.ED25519Key)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	pemBytes, err := e.ExportKey(pub.ID(), passphrase)
+	if err != nil {
+		t.Fatalf("ExportKey: %v", err)
+	}
+
+	e2 := NewEd25519()
+	if err := e2.ImportKey(pemBytes, passphrase); err != nil {
+		t.Fatalf("ImportKey: %v", err)
+	}
+
+	got := e2.GetKey(pub.ID())
+	if got == nil || !bytes.Equal(got.Public(), pub.Public()) {
+		t.Fatalf("imported key does not match exported key")
+	}
+}
+
+func TestEd25519ImportKeyWrongPassphraseFails(t *testing.T) {
+	e := NewEd25519()
+	pub, err := e.Create("targets", data.ED25519Key)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	pemBytes, err := e.ExportKey(pub.ID(), []byte("right passphrase"))
+	if err != nil {
+		t.Fatalf("ExportKey: %v", err)
+	}
+
+	if err := NewEd25519().ImportKey(pemBytes, []byte("wrong passphrase")); err == nil {
+		t.Fatal("ImportKey succeeded with the wrong passphrase")
+	}
+}
+
+func TestEd25519ImportRootKeyRequiresEncryption(t *testing.T) {
+	plaintext := pem.EncodeToMemory(&pem.Block{
+		Type: "PRIVATE KEY",
+		Headers: map[string]string{
+			"role":  "root",
+			"keyid": "deadbeef",
+		},
+		Bytes: make([]byte, 96),
+	})
+
+	if err := NewEd25519().ImportKey(plaintext, nil); err != ErrRootKeyNotEncrypted {
+		t.Fatalf("ImportKey error = %v, want ErrRootKeyNotEncrypted", err)
+	}
+}
+
+func TestEd25519ExportAllKeysBundlesEveryKey(t *testing.T) {
+	passphrase := []byte("backup phrase")
+
+	e := NewEd25519()
+	if _, err := e.Create("targets", data.ED25519Key); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := e.Create("snapshot", data.ED25519Key); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	zipBytes, err := e.ExportAllKeys(passphrase)
+	if err != nil {
+		t.Fatalf("ExportAllKeys: %v", err)
+	}
+	if len(zipBytes) == 0 {
+		t.Fatal("ExportAllKeys returned no data")
+	}
+}
+
+// fakeKeyStore is a minimal trustmanager.KeyStore used to exercise
+// CryptoService.ImportKey without pulling in Ed25519's own length checks.
+type fakeKeyStore struct {
+	added []data.PrivateKey
+}
+
+func (f *fakeKeyStore) AddKey(role, gun string, privKey data.PrivateKey) error {
+	f.added = append(f.added, privKey)
+	return nil
+}
+
+func (f *fakeKeyStore) GetKey(keyID string) data.PublicKey {
+	return nil
+}
+
+func (f *fakeKeyStore) GetPrivateKey(keyID string) (data.PrivateKey, string, error) {
+	return nil, "", trustmanager.ErrKeyNotFound{KeyID: keyID}
+}
+
+func (f *fakeKeyStore) RemoveKey(keyID string) error {
+	return nil
+}
+
+func (f *fakeKeyStore) ListAllKeys() map[string]string {
+	return nil
+}
+
+func (f *fakeKeyStore) Name() string {
+	return "fake"
+}
+
+// TestCryptoServiceImportKeyRejectsOverflowingLengthPrefix is a regression
+// test for a crafted 4-byte public-key length prefix near 2^32 overflowing
+// "4+pubLen" and turning the truncation check into a no-op, which used to
+// panic on the subsequent slice expression instead of returning an error.
+func TestCryptoServiceImportKeyRejectsOverflowingLengthPrefix(t *testing.T) {
+	raw := make([]byte, 8)
+	binary.BigEndian.PutUint32(raw, 0xFFFFFFFC)
+
+	pemBytes := pem.EncodeToMemory(&pem.Block{
+		Type: "PRIVATE KEY",
+		Headers: map[string]string{
+			"role":      "targets",
+			"algorithm": data.ED25519Key,
+		},
+		Bytes: raw,
+	})
+
+	cs := NewCryptoService(&fakeKeyStore{})
+	if err := cs.ImportKey(pemBytes, nil); err == nil {
+		t.Fatal("ImportKey accepted a key with an overflowing length prefix")
+	}
+}
@@ -0,0 +1,60 @@
+package signed
+
+import (
+	"errors"
+
+	"github.com/agl/ed25519"
+	"github.com/docker/notary/tuf/data"
+)
+
+// ErrInvalidSignature is returned by Verify when a signature does not
+// validate against the given public key and message. VerifyBatch does not
+// return it: it lists such signatures' key IDs in invalid instead.
+var ErrInvalidSignature = errors.New("signed: invalid signature")
+
+// ErrUnsupportedSignatureMethod is returned when asked to verify a
+// signature whose Method isn't data.EDDSASignature.
+var ErrUnsupportedSignatureMethod = errors.New("signed: unsupported signature method")
+
+// Verify checks that sig is a valid EDDSA signature by pub over msg.
+func (e *Ed25519) Verify(pub data.PublicKey, sig data.Signature, msg []byte) error {
+	if sig.Method != data.EDDSASignature {
+		return ErrUnsupportedSignatureMethod
+	}
+
+	var pubBytes [ed25519.PublicKeySize]byte
+	copy(pubBytes[:], pub.Public())
+
+	var sigBytes [ed25519.SignatureSize]byte
+	copy(sigBytes[:], sig.Signature)
+
+	if !ed25519.Verify(&pubBytes, msg, &sigBytes) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// VerifyBatch checks sigs against msg, one by one, matching each signature
+// to the key in keys with the same ID. It returns the IDs of the keys whose
+// signature validated and the IDs of those that didn't, which is what a TUF
+// threshold check needs to know.
+func (e *Ed25519) VerifyBatch(keys []data.PublicKey, sigs []data.Signature, msg []byte) (valid, invalid []string, err error) {
+	byID := make(map[string]data.PublicKey, len(keys))
+	for _, k := range keys {
+		byID[k.ID()] = k
+	}
+
+	for _, sig := range sigs {
+		pub, ok := byID[sig.KeyID]
+		if !ok {
+			invalid = append(invalid, sig.KeyID)
+			continue
+		}
+		if verifyErr := e.Verify(pub, sig, msg); verifyErr != nil {
+			invalid = append(invalid, sig.KeyID)
+			continue
+		}
+		valid = append(valid, sig.KeyID)
+	}
+	return valid, invalid, nil
+}
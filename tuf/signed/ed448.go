@@ -0,0 +1,164 @@
+package signed
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"github.com/cloudflare/circl/sign/ed448"
+	"github.com/docker/notary/tuf/data"
+)
+
+type ed448Key struct {
+	role    string
+	privKey data.PrivateKey
+}
+
+// Ed448 implements a simple in memory cryptosystem for Ed448 keys, offering
+// a higher security level than Ed25519 for operators willing to pay its
+// larger key and signature sizes. Its API mirrors Ed25519.
+type Ed448 struct {
+	keys map[string]ed448Key
+}
+
+// NewEd448 initializes a new empty Ed448 CryptoService that operates
+// entirely in memory
+func NewEd448() *Ed448 {
+	return &Ed448{
+		make(map[string]ed448Key),
+	}
+}
+
+// addKey allows you to add a private key
+func (e *Ed448) addKey(role string, k data.PrivateKey) {
+	e.keys[k.ID()] = ed448Key{
+		role:    role,
+		privKey: k,
+	}
+}
+
+// AddKey stores privKey under role, satisfying trustmanager.KeyStore. gun is
+// ignored: this store is not GUN-scoped.
+func (e *Ed448) AddKey(role, gun string, privKey data.PrivateKey) error {
+	e.addKey(role, privKey)
+	return nil
+}
+
+// Name identifies this key store backend, satisfying trustmanager.KeyStore.
+func (e *Ed448) Name() string {
+	return "memory"
+}
+
+// RemoveKey deletes a key from the signer
+func (e *Ed448) RemoveKey(keyID string) error {
+	delete(e.keys, keyID)
+	return nil
+}
+
+// ListKeys returns the list of keys IDs for the role
+func (e *Ed448) ListKeys(role string) []string {
+	keyIDs := make([]string, 0, len(e.keys))
+	for id, k := range e.keys {
+		if k.role == role {
+			keyIDs = append(keyIDs, id)
+		}
+	}
+	return keyIDs
+}
+
+// ListAllKeys returns a map of keyID to role
+func (e *Ed448) ListAllKeys() map[string]string {
+	keys := make(map[string]string)
+	for id, edKey := range e.keys {
+		keys[id] = edKey.role
+	}
+	return keys
+}
+
+// Sign generates an Ed448 signature over the data
+func (e *Ed448) Sign(keyIDs []string, toSign []byte) ([]data.Signature, error) {
+	signatures := make([]data.Signature, 0, len(keyIDs))
+	for _, keyID := range keyIDs {
+		key, ok := e.keys[keyID]
+		if !ok {
+			return nil, fmt.Errorf("signed: unknown key: %s", keyID)
+		}
+		sig := ed448.Sign(ed448.PrivateKey(key.privKey.Private()), toSign, "")
+		signatures = append(signatures, data.Signature{
+			KeyID:     keyID,
+			Method:    data.ED448Signature,
+			Signature: sig,
+		})
+	}
+	return signatures, nil
+}
+
+// Create generates a new key and returns the public part
+func (e *Ed448) Create(role string) (data.PublicKey, error) {
+	public, private, err := generateED448KeyPair()
+	if err != nil {
+		return nil, err
+	}
+
+	e.addKey(role, private)
+	return public, nil
+}
+
+// generateED448KeyPair generates a new in-process Ed448 key pair, shared by
+// Ed448.Create and CryptoService's generic Create.
+func generateED448KeyPair() (data.PublicKey, data.PrivateKey, error) {
+	pub, priv, err := ed448.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	public, err := data.NewED448PublicKey(pub)
+	if err != nil {
+		return nil, nil, err
+	}
+	private, err := data.NewED448PrivateKey(*public, priv)
+	if err != nil {
+		return nil, nil, err
+	}
+	return public, private, nil
+}
+
+// Verify checks that sig is a valid Ed448 signature by pub over msg.
+func (e *Ed448) Verify(pub data.PublicKey, msg, sig []byte) error {
+	if len(pub.Public()) != ed448.PublicKeySize {
+		return errors.New("signed: invalid ed448 public key")
+	}
+	if !ed448.Verify(ed448.PublicKey(pub.Public()), msg, sig, "") {
+		return errors.New("signed: invalid ed448 signature")
+	}
+	return nil
+}
+
+// PublicKeys returns a map of public keys for the ids provided, when those IDs are found
+// in the store.
+func (e *Ed448) PublicKeys(keyIDs ...string) (map[string]data.PublicKey, error) {
+	k := make(map[string]data.PublicKey)
+	for _, keyID := range keyIDs {
+		if edKey, ok := e.keys[keyID]; ok {
+			k[keyID] = data.PublicKeyFromPrivate(edKey.privKey)
+		}
+	}
+	return k, nil
+}
+
+// GetKey returns a single public key based on the ID
+func (e *Ed448) GetKey(keyID string) data.PublicKey {
+	edKey, ok := e.keys[keyID]
+	if !ok {
+		return nil
+	}
+	return data.PublicKeyFromPrivate(edKey.privKey)
+}
+
+// GetPrivateKey returns a single private key based on the ID
+func (e *Ed448) GetPrivateKey(keyID string) (data.PrivateKey, string, error) {
+	edKey, ok := e.keys[keyID]
+	if !ok {
+		return nil, "", fmt.Errorf("signed: unknown key: %s", keyID)
+	}
+	return edKey.privKey, edKey.role, nil
+}
@@ -0,0 +1,64 @@
+package signed
+
+import (
+	"testing"
+
+	"github.com/docker/notary/tuf/data"
+	"github.com/docker/notary/tuf/trustmanager"
+)
+
+func TestEd448IsAKeyStore(t *testing.T) {
+	var _ trustmanager.KeyStore = NewEd448()
+}
+
+func TestEd448CreateSignVerify(t *testing.T) {
+	e := NewEd448()
+
+	pub, err := e.Create("targets")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if pub.Algorithm() != data.ED448Key {
+		t.Fatalf("Create produced a %s key, want %s", pub.Algorithm(), data.ED448Key)
+	}
+
+	msg := []byte("attack at dawn")
+	sigs, err := e.Sign([]string{pub.ID()}, msg)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if len(sigs) != 1 || sigs[0].Method != data.ED448Signature {
+		t.Fatalf("Sign returned unexpected signatures: %+v", sigs)
+	}
+
+	if err := e.Verify(pub, msg, sigs[0].Signature); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if err := e.Verify(pub, []byte("a different message"), sigs[0].Signature); err == nil {
+		t.Fatal("Verify accepted a signature over the wrong message")
+	}
+}
+
+func TestEd448CryptoServiceCreateSign(t *testing.T) {
+	cs := NewCryptoService(NewEd448())
+
+	pub, err := cs.Create("targets", "", data.ED448Key)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	msg := []byte("attack at dawn")
+	sigs, err := cs.Sign([]string{pub.ID()}, msg)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	if sigs[0].Method != data.ED448Signature {
+		t.Fatalf("Sign produced method %s, want %s", sigs[0].Method, data.ED448Signature)
+	}
+}
+
+func TestEd448GetPrivateKeyUnknownKeyErrors(t *testing.T) {
+	if _, _, err := NewEd448().GetPrivateKey("nonexistent"); err == nil {
+		t.Fatal("GetPrivateKey succeeded on an unknown key ID")
+	}
+}
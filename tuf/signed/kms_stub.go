@@ -0,0 +1,58 @@
+package signed
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+
+	"github.com/docker/notary/tuf/data"
+)
+
+// StubKMSSigner is a trivial in-memory KMSSigner. It holds its keys just
+// like ECDSA's own local storage does, but is signed through the KMSSigner
+// interface, so callers and tests exercise the same delegation path a real
+// KMS integration (AWS KMS, GCP KMS, Azure Key Vault) would use, without
+// needing network access or cloud credentials.
+type StubKMSSigner struct {
+	mu   sync.Mutex
+	keys map[string]*ecdsa.PrivateKey
+}
+
+// NewStubKMSSigner returns an empty StubKMSSigner.
+func NewStubKMSSigner() *StubKMSSigner {
+	return &StubKMSSigner{keys: make(map[string]*ecdsa.PrivateKey)}
+}
+
+// Generate creates a new P-256 key inside the stub KMS and returns its
+// public half, for registering with ECDSA.AddKMSKey under a role.
+func (s *StubKMSSigner) Generate() (data.PublicKey, error) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	public := data.NewECDSAPublicKey(elliptic.Marshal(elliptic.P256(), priv.X, priv.Y))
+
+	s.mu.Lock()
+	s.keys[public.ID()] = priv
+	s.mu.Unlock()
+
+	return public, nil
+}
+
+// Sign produces a signature over msg with the key identified by keyID,
+// satisfying KMSSigner. The private key never leaves this type, mirroring
+// how a real remote KMS would never hand back key material.
+func (s *StubKMSSigner) Sign(keyID string, msg []byte) ([]byte, error) {
+	s.mu.Lock()
+	priv, ok := s.keys[keyID]
+	s.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("signed: stub KMS has no key %s", keyID)
+	}
+
+	hashed := sha256.Sum256(msg)
+	return ecdsa.SignASN1(rand.Reader, priv, hashed[:])
+}
@@ -0,0 +1,53 @@
+// Package trustmanager defines the storage interfaces that signed.CryptoService
+// uses to hold private keys, independent of where those keys actually live.
+package trustmanager
+
+import (
+	"fmt"
+
+	"github.com/docker/notary/tuf/data"
+)
+
+// KeyStore persists private keys for one or more roles, keyed by key ID, so
+// a signed.CryptoService can locate a key regardless of which backend holds
+// it.
+type KeyStore interface {
+	// AddKey stores privKey, associated with role and gun.
+	AddKey(role, gun string, privKey data.PrivateKey) error
+	// GetKey returns the public key for keyID, or nil if it isn't held by
+	// this store. Unlike GetPrivateKey, this works even for keys whose
+	// private material never leaves a remote signer.
+	GetKey(keyID string) data.PublicKey
+	// GetPrivateKey returns the private key and role for keyID. It returns
+	// an error if keyID isn't held by this store; for a key registered
+	// without local private material (e.g. a remote KMS key), it returns a
+	// nil PrivateKey and a nil error.
+	GetPrivateKey(keyID string) (data.PrivateKey, string, error)
+	// RemoveKey removes the private key for keyID from the store.
+	RemoveKey(keyID string) error
+	// ListAllKeys returns a map of keyID to role for every key in the store.
+	ListAllKeys() map[string]string
+	// Name identifies the key store backend, for logging and diagnostics.
+	Name() string
+}
+
+// Signer is optionally implemented by a KeyStore that can produce a
+// signature itself, rather than exposing private key material via
+// GetPrivateKey. A store backed by a remote KMS implements this so
+// CryptoService can delegate signing for keys it never sees.
+type Signer interface {
+	KeyStore
+	// SignWithKey produces a signature over toSign using the key
+	// identified by keyID, which must already be held by this store.
+	SignWithKey(keyID string, toSign []byte) (data.Signature, error)
+}
+
+// ErrKeyNotFound is returned when no configured key store holds the
+// requested key ID.
+type ErrKeyNotFound struct {
+	KeyID string
+}
+
+func (err ErrKeyNotFound) Error() string {
+	return fmt.Sprintf("signing key not found: %s", err.KeyID)
+}